@@ -2,10 +2,9 @@ module github.com/pratikgajjar/fambot-go
 
 go 1.18
 
+require github.com/slack-go/slack v0.11.0
+
 require (
 	github.com/gorilla/websocket v1.4.2 // indirect
-	github.com/shomali11/commander v0.0.0-20220716022157-b5248c76541a // indirect
-	github.com/shomali11/proper v0.0.0-20180607004733-233a9a872c30 // indirect
-	github.com/shomali11/slacker v1.2.0 // indirect
-	github.com/slack-go/slack v0.11.0 // indirect
+	github.com/stretchr/testify v1.3.0 // indirect
 )
@@ -0,0 +1,202 @@
+// Package circuitbreaker stops calling the Slack API while it is failing,
+// so an outage doesn't turn into a flood of doomed requests and log lines.
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// ErrCircuitOpen is returned by Call without invoking the wrapped function
+// while the breaker is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+const (
+	failureThreshold = 5
+	failureWindow    = 30 * time.Second
+	openTimeout      = 60 * time.Second
+)
+
+// State is the current state of a Breaker.
+type State int
+
+const (
+	Closed State = iota
+	Open
+	HalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// IsOutage reports whether err suggests Slack itself is unreachable or
+// failing: a transport error, a timeout or an HTTP 5xx response. API
+// errors such as channel_not_found or not_in_channel, and rate limiting,
+// mean Slack answered and are not outages.
+func IsOutage(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var statusErr slack.StatusCodeError
+	if errors.As(err, &statusErr) {
+		return statusErr.Code >= 500
+	}
+
+	var netErr net.Error
+	var urlErr *url.Error
+	return errors.As(err, &netErr) ||
+		errors.As(err, &urlErr) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// Breaker opens after 5 consecutive failures within 30 seconds. Once open,
+// every call fails fast with ErrCircuitOpen for 60 seconds, after which a
+// single trial call is let through: success closes the breaker again,
+// failure re-opens it.
+//
+// Each state change starts a new generation. A call only affects the
+// breaker if it finishes in the generation it started in, so a slow call
+// from before the breaker opened can't be mistaken for the half-open trial
+// or push back the end of the open period.
+type Breaker struct {
+	name      string
+	isFailure func(error) bool
+	now       func() time.Time
+
+	mu           sync.Mutex
+	state        State
+	generation   uint64
+	failures     int
+	firstFailure time.Time
+	openedAt     time.Time
+	trialRunning bool
+}
+
+// New returns a closed Breaker. name is only used in log lines. isFailure
+// decides which errors count towards opening the breaker; other errors
+// are treated like successes. A nil isFailure means IsOutage.
+func New(name string, isFailure func(error) bool) *Breaker {
+	if isFailure == nil {
+		isFailure = IsOutage
+	}
+	return &Breaker{name: name, isFailure: isFailure, now: time.Now}
+}
+
+// State reports the breaker's current state.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// Call runs fn unless the breaker is open, and records its outcome. If fn
+// panics, the panic counts as a failure and is passed on to the caller.
+// An error that isFailure rejects still reaches the caller, but counts as
+// a success for the breaker.
+func (b *Breaker) Call(fn func() error) error {
+	generation, err := b.before()
+	if err != nil {
+		return err
+	}
+
+	returned := false
+	defer func() {
+		if !returned {
+			b.after(generation, true)
+		}
+	}()
+	err = fn()
+	returned = true
+	b.after(generation, b.isFailure(err))
+	return err
+}
+
+func (b *Breaker) before() (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case Open:
+		if b.now().Sub(b.openedAt) < openTimeout {
+			return 0, ErrCircuitOpen
+		}
+		b.setState(HalfOpen)
+		b.trialRunning = true
+	case HalfOpen:
+		if b.trialRunning {
+			return 0, ErrCircuitOpen
+		}
+		b.trialRunning = true
+	}
+	return b.generation, nil
+}
+
+func (b *Breaker) after(generation uint64, failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if generation != b.generation {
+		return
+	}
+
+	if b.state == HalfOpen {
+		b.trialRunning = false
+		if failed {
+			b.open()
+		} else {
+			b.failures = 0
+			b.setState(Closed)
+		}
+		return
+	}
+
+	if !failed {
+		b.failures = 0
+		return
+	}
+
+	now := b.now()
+	if b.failures == 0 || now.Sub(b.firstFailure) > failureWindow {
+		b.failures = 0
+		b.firstFailure = now
+	}
+	b.failures++
+	if b.failures >= failureThreshold {
+		b.open()
+	}
+}
+
+func (b *Breaker) open() {
+	b.failures = 0
+	b.openedAt = b.now()
+	b.setState(Open)
+}
+
+func (b *Breaker) setState(s State) {
+	if b.state == s {
+		return
+	}
+	log.Printf("Circuit breaker %q: %s -> %s", b.name, b.state, s)
+	b.state = s
+	b.generation++
+}
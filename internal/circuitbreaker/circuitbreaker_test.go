@@ -0,0 +1,322 @@
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+var errSlack = slack.StatusCodeError{Code: 503, Status: "503 Service Unavailable"}
+
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time          { return c.t }
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestBreaker() (*Breaker, *fakeClock) {
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	b := New("test", nil)
+	b.now = clock.now
+	return b, clock
+}
+
+func fail() error    { return errSlack }
+func succeed() error { return nil }
+
+func failTimes(t *testing.T, b *Breaker, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		if err := b.Call(fail); !errors.Is(err, errSlack) {
+			t.Fatalf("call %d: err = %v, want %v", i+1, err, errSlack)
+		}
+	}
+}
+
+func openBreaker(t *testing.T) (*Breaker, *fakeClock) {
+	t.Helper()
+	b, clock := newTestBreaker()
+	failTimes(t, b, failureThreshold)
+	if b.State() != Open {
+		t.Fatalf("state = %s, want open", b.State())
+	}
+	return b, clock
+}
+
+func TestOpensAfterConsecutiveFailures(t *testing.T) {
+	b, _ := newTestBreaker()
+
+	failTimes(t, b, failureThreshold-1)
+	if b.State() != Closed {
+		t.Fatalf("state after %d failures = %s, want closed", failureThreshold-1, b.State())
+	}
+
+	failTimes(t, b, 1)
+	if b.State() != Open {
+		t.Fatalf("state after %d failures = %s, want open", failureThreshold, b.State())
+	}
+}
+
+func TestSuccessResetsFailureCount(t *testing.T) {
+	b, _ := newTestBreaker()
+
+	failTimes(t, b, failureThreshold-1)
+	if err := b.Call(succeed); err != nil {
+		t.Fatalf("err = %v", err)
+	}
+	failTimes(t, b, failureThreshold-1)
+	if b.State() != Closed {
+		t.Fatalf("state = %s, want closed", b.State())
+	}
+}
+
+func TestFailureWindowResetsCount(t *testing.T) {
+	b, clock := newTestBreaker()
+
+	failTimes(t, b, failureThreshold-1)
+	clock.advance(failureWindow + time.Second)
+	failTimes(t, b, 1)
+	if b.State() != Closed {
+		t.Fatalf("state = %s, want closed once the window has passed", b.State())
+	}
+
+	failTimes(t, b, failureThreshold-1)
+	if b.State() != Open {
+		t.Fatalf("state = %s, want open after %d failures in a new window", b.State(), failureThreshold)
+	}
+}
+
+func TestOpenFailsFast(t *testing.T) {
+	b, clock := openBreaker(t)
+	clock.advance(openTimeout - time.Second)
+
+	called := false
+	err := b.Call(func() error {
+		called = true
+		return nil
+	})
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("err = %v, want ErrCircuitOpen", err)
+	}
+	if called {
+		t.Error("fn was called while the breaker was open")
+	}
+}
+
+func TestHalfOpenAllowsSingleTrial(t *testing.T) {
+	b, clock := openBreaker(t)
+	clock.advance(openTimeout)
+
+	var nested error
+	err := b.Call(func() error {
+		if b.State() != HalfOpen {
+			t.Errorf("state during trial = %s, want half-open", b.State())
+		}
+		nested = b.Call(succeed)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("trial err = %v", err)
+	}
+	if !errors.Is(nested, ErrCircuitOpen) {
+		t.Errorf("second call during trial: err = %v, want ErrCircuitOpen", nested)
+	}
+}
+
+func TestTrialSuccessCloses(t *testing.T) {
+	b, clock := openBreaker(t)
+	clock.advance(openTimeout)
+
+	if err := b.Call(succeed); err != nil {
+		t.Fatalf("err = %v", err)
+	}
+	if b.State() != Closed {
+		t.Fatalf("state = %s, want closed", b.State())
+	}
+	failTimes(t, b, failureThreshold-1)
+	if b.State() != Closed {
+		t.Fatalf("state = %s, want closed with a fresh failure count", b.State())
+	}
+}
+
+func TestTrialFailureReopens(t *testing.T) {
+	b, clock := openBreaker(t)
+	clock.advance(openTimeout)
+
+	if err := b.Call(fail); !errors.Is(err, errSlack) {
+		t.Fatalf("err = %v, want %v", err, errSlack)
+	}
+	if b.State() != Open {
+		t.Fatalf("state = %s, want open", b.State())
+	}
+
+	clock.advance(openTimeout - time.Second)
+	if err := b.Call(succeed); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("err = %v, want ErrCircuitOpen for a full open period after the failed trial", err)
+	}
+}
+
+func TestStaleCallIgnored(t *testing.T) {
+	b, clock := newTestBreaker()
+	failTimes(t, b, failureThreshold-1)
+
+	// A slow call starts while closed and only fails after the breaker has
+	// opened and moved to half-open.
+	var trialErr error
+	err := b.Call(func() error {
+		failTimes(t, b, 1)
+		clock.advance(openTimeout)
+		trialErr = b.Call(func() error {
+			if b.State() != HalfOpen {
+				t.Errorf("state during trial = %s, want half-open", b.State())
+			}
+			return nil
+		})
+		return errSlack
+	})
+	if !errors.Is(err, errSlack) {
+		t.Fatalf("err = %v, want %v", err, errSlack)
+	}
+	if trialErr != nil {
+		t.Fatalf("trial err = %v", trialErr)
+	}
+	if b.State() != Closed {
+		t.Errorf("state = %s, want closed: the stale failure must not override the trial", b.State())
+	}
+}
+
+func TestLateFailureDoesNotExtendOpenPeriod(t *testing.T) {
+	b, clock := newTestBreaker()
+	failTimes(t, b, failureThreshold-1)
+
+	err := b.Call(func() error {
+		failTimes(t, b, 1)
+		clock.advance(openTimeout - time.Second)
+		return errSlack
+	})
+	if !errors.Is(err, errSlack) {
+		t.Fatalf("err = %v, want %v", err, errSlack)
+	}
+
+	clock.advance(time.Second)
+	if err := b.Call(succeed); err != nil {
+		t.Errorf("err = %v, want trial call to be allowed once the original open period ends", err)
+	}
+}
+
+// callPanicking runs a panicking fn through b and reports whether the panic
+// reached the caller.
+func callPanicking(b *Breaker) (panicked bool) {
+	defer func() {
+		panicked = recover() != nil
+	}()
+	b.Call(func() error { panic("boom") })
+	return false
+}
+
+func TestPanickingTrialReopens(t *testing.T) {
+	b, clock := openBreaker(t)
+	clock.advance(openTimeout)
+
+	if !callPanicking(b) {
+		t.Fatal("panic from the trial call was swallowed")
+	}
+	if b.State() != Open {
+		t.Fatalf("state = %s, want open after a panicking trial", b.State())
+	}
+
+	clock.advance(openTimeout)
+	if err := b.Call(succeed); err != nil {
+		t.Fatalf("err = %v, want the next trial to be allowed", err)
+	}
+	if b.State() != Closed {
+		t.Errorf("state = %s, want closed", b.State())
+	}
+}
+
+func TestPanicCountsAsFailure(t *testing.T) {
+	b, _ := newTestBreaker()
+	failTimes(t, b, failureThreshold-1)
+
+	if !callPanicking(b) {
+		t.Fatal("panic was swallowed")
+	}
+	if b.State() != Open {
+		t.Errorf("state = %s, want open", b.State())
+	}
+}
+
+func TestNonOutageErrorsLeaveBreakerClosed(t *testing.T) {
+	errs := []error{
+		errors.New("channel_not_found"),
+		errors.New("not_in_channel"),
+		&slack.RateLimitedError{RetryAfter: time.Second},
+		slack.StatusCodeError{Code: 404, Status: "404 Not Found"},
+	}
+
+	b, _ := newTestBreaker()
+	for i := 0; i < failureThreshold; i++ {
+		for _, want := range errs {
+			if err := b.Call(func() error { return want }); !errors.Is(err, want) {
+				t.Fatalf("err = %v, want %v passed through", err, want)
+			}
+		}
+	}
+	if b.State() != Closed {
+		t.Errorf("state = %s, want closed", b.State())
+	}
+}
+
+func TestNonOutageErrorResetsFailureCount(t *testing.T) {
+	b, _ := newTestBreaker()
+
+	failTimes(t, b, failureThreshold-1)
+	b.Call(func() error { return errors.New("channel_not_found") })
+	failTimes(t, b, failureThreshold-1)
+	if b.State() != Closed {
+		t.Errorf("state = %s, want closed", b.State())
+	}
+}
+
+func TestCustomFailureClassifier(t *testing.T) {
+	errBoom := errors.New("boom")
+	b := New("test", func(err error) bool { return errors.Is(err, errBoom) })
+
+	for i := 0; i < failureThreshold; i++ {
+		b.Call(func() error { return errBoom })
+	}
+	if b.State() != Open {
+		t.Errorf("state = %s, want open", b.State())
+	}
+}
+
+func TestIsOutage(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"5xx", slack.StatusCodeError{Code: 502, Status: "502 Bad Gateway"}, true},
+		{"wrapped 5xx", fmt.Errorf("post: %w", slack.StatusCodeError{Code: 500}), true},
+		{"4xx", slack.StatusCodeError{Code: 404}, false},
+		{"transport", &url.Error{Op: "Post", URL: "https://slack.com/api/chat.postMessage", Err: errors.New("connection refused")}, true},
+		{"net error", &net.OpError{Op: "dial", Err: errors.New("no route to host")}, true},
+		{"deadline", context.DeadlineExceeded, true},
+		{"api error", errors.New("channel_not_found"), false},
+		{"rate limited", &slack.RateLimitedError{RetryAfter: time.Second}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsOutage(tt.err); got != tt.want {
+				t.Errorf("IsOutage(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+// Package cmdsuggest suggests the slash command a user probably meant when
+// they typed one that doesn't exist.
+package cmdsuggest
+
+import "strings"
+
+// Closest returns the command in commands nearest to input by edit
+// distance, or "" if none is close enough to be a plausible typo. A
+// candidate counts as close when it needs at most len(input)/2 edits,
+// slash included. Matching ignores case and a missing leading slash.
+// Ties go to the command listed first.
+func Closest(input string, commands []string) string {
+	input = normalize(input)
+	if input == "/" {
+		return ""
+	}
+	maxDistance := len([]rune(input)) / 2
+
+	best, bestDistance := "", maxDistance+1
+	for _, cmd := range commands {
+		if d := distance(input, normalize(cmd)); d < bestDistance {
+			best, bestDistance = cmd, d
+		}
+	}
+	return best
+}
+
+func normalize(cmd string) string {
+	cmd = strings.ToLower(strings.TrimSpace(cmd))
+	if !strings.HasPrefix(cmd, "/") {
+		cmd = "/" + cmd
+	}
+	return cmd
+}
+
+// distance is the Levenshtein distance between a and b, counted in runes.
+func distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min3(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
@@ -0,0 +1,66 @@
+package cmdsuggest
+
+import "testing"
+
+var commands = []string{
+	"/my-karma",
+	"/top-karma",
+	"/set-birthday",
+	"/set-anniversary",
+	"/view-anniversary",
+	"/fambot-help",
+}
+
+func TestClosest(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"/karma", "/my-karma"},
+		{"/my-karm", "/my-karma"},
+		{"/top-krama", "/top-karma"},
+		{"/set-bday", "/set-birthday"},
+		{"/set-aniversary", "/set-anniversary"},
+		{"/SET-BIRTHDAY", "/set-birthday"},
+		{"fambot-hlep", "/fambot-help"},
+		{"/my-karma", "/my-karma"},
+		{"/deploy-prod", ""},
+		{"/x", ""},
+		{"/", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := Closest(tt.input, commands); got != tt.want {
+				t.Errorf("Closest(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClosestNoCommands(t *testing.T) {
+	if got := Closest("/karma", nil); got != "" {
+		t.Errorf("Closest with no commands = %q, want \"\"", got)
+	}
+}
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"", "abc", 3},
+		{"kitten", "sitting", 3},
+		{"/karma", "/my-karma", 3},
+		{"🎂cake", "🎉cake", 1},
+	}
+
+	for _, tt := range tests {
+		if got := distance(tt.a, tt.b); got != tt.want {
+			t.Errorf("distance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
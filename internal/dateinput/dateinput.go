@@ -0,0 +1,66 @@
+// Package dateinput parses the dates users type into slash commands such as
+// /set-birthday and /set-anniversary.
+package dateinput
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Parse reads MM/DD or MM/DD/YYYY, with single-digit months and days
+// allowed and "-" accepted in place of "/". year is 0 when it was left
+// out. The date must exist on the calendar; Feb 29 is accepted without a
+// year, and with a year only if that year is a leap year.
+func Parse(text string) (month, day, year int, err error) {
+	text = strings.TrimSpace(text)
+	parts := strings.FieldsFunc(text, func(r rune) bool { return r == '/' || r == '-' })
+	if (len(parts) != 2 && len(parts) != 3) || strings.Count(text, "/")+strings.Count(text, "-") != len(parts)-1 {
+		return 0, 0, 0, fmt.Errorf("invalid date %q, expected MM/DD or MM/DD/YYYY", text)
+	}
+
+	month, err = number(parts[0], 1, 2)
+	if err != nil || month < 1 || month > 12 {
+		return 0, 0, 0, fmt.Errorf("invalid month %q, expected 1-12", parts[0])
+	}
+
+	day, err = number(parts[1], 1, 2)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("invalid day %q", parts[1])
+	}
+
+	if len(parts) == 3 {
+		year, err = number(parts[2], 4, 4)
+		if err != nil || year < 1900 {
+			return 0, 0, 0, fmt.Errorf("invalid year %q, expected four digits from 1900", parts[2])
+		}
+	}
+
+	if max := daysIn(month, year); day < 1 || day > max {
+		return 0, 0, 0, fmt.Errorf("invalid day %d for %s, expected 1-%d", day, time.Month(month), max)
+	}
+	return month, day, year, nil
+}
+
+// number parses s as a non-negative integer of minDigits to maxDigits.
+func number(s string, minDigits, maxDigits int) (int, error) {
+	if len(s) < minDigits || len(s) > maxDigits {
+		return 0, fmt.Errorf("want %d-%d digits", minDigits, maxDigits)
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return 0, fmt.Errorf("not a number")
+		}
+	}
+	return strconv.Atoi(s)
+}
+
+// daysIn returns the number of days in month. A year of 0 means unknown, so
+// February allows the 29th.
+func daysIn(month, year int) int {
+	if year == 0 {
+		year = 2000
+	}
+	return time.Date(year, time.Month(month)+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
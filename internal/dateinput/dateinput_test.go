@@ -0,0 +1,50 @@
+package dateinput
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input                        string
+		wantMonth, wantDay, wantYear int
+		wantErr                      bool
+	}{
+		{"03/15", 3, 15, 0, false},
+		{"3/5", 3, 5, 0, false},
+		{"  12/31  ", 12, 31, 0, false},
+		{"03/15/1990", 3, 15, 1990, false},
+		{"03-15-1990", 3, 15, 1990, false},
+		{"02/29", 2, 29, 0, false},
+		{"02/29/2000", 2, 29, 2000, false},
+		{"02/29/2024", 2, 29, 2024, false},
+		{"02/29/2023", 0, 0, 0, true},
+		{"02/29/1900", 0, 0, 0, true},
+		{"02/30", 0, 0, 0, true},
+		{"04/31", 0, 0, 0, true},
+		{"13/01", 0, 0, 0, true},
+		{"00/10", 0, 0, 0, true},
+		{"01/00", 0, 0, 0, true},
+		{"01/32", 0, 0, 0, true},
+		{"03/15/90", 0, 0, 0, true},
+		{"03/15/1899", 0, 0, 0, true},
+		{"03/15/19901", 0, 0, 0, true},
+		{"03//15", 0, 0, 0, true},
+		{"03/15/", 0, 0, 0, true},
+		{"/03/15", 0, 0, 0, true},
+		{"03/15/1990/1", 0, 0, 0, true},
+		{"March 15", 0, 0, 0, true},
+		{"+3/15", 0, 0, 0, true},
+		{"", 0, 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			month, day, year, err := Parse(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse(%q) err = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if month != tt.wantMonth || day != tt.wantDay || year != tt.wantYear {
+				t.Errorf("Parse(%q) = %d, %d, %d, want %d, %d, %d", tt.input, month, day, year, tt.wantMonth, tt.wantDay, tt.wantYear)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package dburl
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		wantDriver string
+		wantDSN    string
+		wantErr    bool
+	}{
+		{"postgres", "postgres://bot:secret@db:5432/fambot?sslmode=disable", DriverPostgres, "postgres://bot:secret@db:5432/fambot?sslmode=disable", false},
+		{"postgresql alias", "postgresql://bot@db/fambot", DriverPostgres, "postgresql://bot@db/fambot", false},
+		{"scheme is case insensitive", "POSTGRES://db/fambot", DriverPostgres, "POSTGRES://db/fambot", false},
+		{"sqlite relative path", "sqlite://fambot.db", DriverSQLite, "fambot.db", false},
+		{"sqlite relative dir", "sqlite://./data/fambot.db", DriverSQLite, "./data/fambot.db", false},
+		{"sqlite absolute path", "sqlite:///var/lib/fambot/fambot.db", DriverSQLite, "/var/lib/fambot/fambot.db", false},
+		{"sqlite keeps options", "sqlite://fambot.db?_busy_timeout=5000&cache=shared", DriverSQLite, "fambot.db?_busy_timeout=5000&cache=shared", false},
+		{"sqlite in memory", "sqlite://:memory:", DriverSQLite, ":memory:", false},
+		{"sqlite3 alias", "sqlite3://fambot.db", DriverSQLite, "fambot.db", false},
+		{"no scheme", "fambot.db", "", "", true},
+		{"empty scheme", "://fambot.db", "", "", true},
+		{"unsupported scheme", "mysql://db/fambot", "", "", true},
+		{"postgres without host", "postgres://", "", "", true},
+		{"sqlite without path", "sqlite://", "", "", true},
+		{"sqlite with only options", "sqlite://?cache=shared", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			driver, dsn, err := Parse(tt.url)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse(%q) err = %v, wantErr %v", tt.url, err, tt.wantErr)
+			}
+			if driver != tt.wantDriver || dsn != tt.wantDSN {
+				t.Errorf("Parse(%q) = (%q, %q), want (%q, %q)", tt.url, driver, dsn, tt.wantDriver, tt.wantDSN)
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		path       string
+		wantDriver string
+		wantDSN    string
+		wantErr    bool
+	}{
+		{"url wins over path", "postgres://db/fambot", "./fambot.db", DriverPostgres, "postgres://db/fambot", false},
+		{"path only", "", "./data/fambot.db", DriverSQLite, "./data/fambot.db", false},
+		{"bad url is not masked by path", "mysql://db", "./fambot.db", "", "", true},
+		{"neither set", "", "", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			driver, dsn, err := Resolve(tt.url, tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Resolve err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if driver != tt.wantDriver || dsn != tt.wantDSN {
+				t.Errorf("Resolve = (%q, %q), want (%q, %q)", driver, dsn, tt.wantDriver, tt.wantDSN)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+// Package lifecycle lets subsystems with buffered or in-flight async work
+// register a flush hook that runs when the process shuts down.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+)
+
+// ErrShutDown is returned by Register once Shutdown has been called.
+var ErrShutDown = errors.New("lifecycle: registry already shut down")
+
+// Closer flushes and releases a subsystem. Close should return promptly
+// once ctx is done.
+type Closer interface {
+	Close(ctx context.Context) error
+}
+
+// CloserFunc adapts a plain function to a Closer.
+type CloserFunc func(ctx context.Context) error
+
+// Close calls f(ctx).
+func (f CloserFunc) Close(ctx context.Context) error {
+	return f(ctx)
+}
+
+type entry struct {
+	name   string
+	closer Closer
+}
+
+// Registry collects Closers and shuts them down in reverse registration
+// order, so a subsystem is closed before the things it depends on.
+type Registry struct {
+	mu      sync.Mutex
+	entries []entry
+	closed  bool
+}
+
+// Register adds c under name. name is only used in log lines and errors.
+// After Shutdown it returns ErrShutDown and c is not added, since it would
+// never be closed; the caller still owns c and must close it itself.
+func (r *Registry) Register(name string, c Closer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return ErrShutDown
+	}
+	r.entries = append(r.entries, entry{name: name, closer: c})
+	return nil
+}
+
+// Shutdown closes every registered Closer, last registered first. Each one
+// runs even if an earlier one failed or ctx has expired, so it can give up
+// quickly on its own. The first error is returned; the rest are logged.
+// Calls after the first are no-ops.
+func (r *Registry) Shutdown(ctx context.Context) error {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return nil
+	}
+	r.closed = true
+	entries := r.entries
+	r.entries = nil
+	r.mu.Unlock()
+
+	var firstErr error
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		if err := e.closer.Close(ctx); err != nil {
+			err = fmt.Errorf("closing %s: %w", e.name, err)
+			if firstErr == nil {
+				firstErr = err
+			} else {
+				log.Printf("Error during shutdown: %v", err)
+			}
+		}
+	}
+	return firstErr
+}
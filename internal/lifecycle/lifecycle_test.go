@@ -0,0 +1,123 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func recordingCloser(name string, order *[]string, err error) Closer {
+	return CloserFunc(func(ctx context.Context) error {
+		*order = append(*order, name)
+		return err
+	})
+}
+
+func TestShutdownClosesInReverseOrder(t *testing.T) {
+	var r Registry
+	var order []string
+	for _, name := range []string{"db", "webhooks", "metrics"} {
+		if err := r.Register(name, recordingCloser(name, &order, nil)); err != nil {
+			t.Fatalf("Register(%q) = %v", name, err)
+		}
+	}
+
+	if err := r.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown = %v", err)
+	}
+
+	want := []string{"metrics", "webhooks", "db"}
+	if !reflect.DeepEqual(order, want) {
+		t.Errorf("close order = %v, want %v", order, want)
+	}
+}
+
+func TestShutdownContinuesAfterFailure(t *testing.T) {
+	var r Registry
+	var order []string
+	errFirst := errors.New("flush failed")
+	errSecond := errors.New("also failed")
+	r.Register("db", recordingCloser("db", &order, errSecond))
+	r.Register("audit", recordingCloser("audit", &order, nil))
+	r.Register("webhooks", recordingCloser("webhooks", &order, errFirst))
+
+	err := r.Shutdown(context.Background())
+
+	want := []string{"webhooks", "audit", "db"}
+	if !reflect.DeepEqual(order, want) {
+		t.Errorf("close order = %v, want %v", order, want)
+	}
+	if !errors.Is(err, errFirst) {
+		t.Errorf("err = %v, want it to wrap %v", err, errFirst)
+	}
+	if got, wantMsg := err.Error(), "closing webhooks: flush failed"; got != wantMsg {
+		t.Errorf("err = %q, want %q", got, wantMsg)
+	}
+}
+
+func TestShutdownTwiceIsNoop(t *testing.T) {
+	var r Registry
+	calls := 0
+	r.Register("db", CloserFunc(func(ctx context.Context) error {
+		calls++
+		return errors.New("boom")
+	}))
+
+	if err := r.Shutdown(context.Background()); err == nil {
+		t.Fatal("first Shutdown = nil, want error")
+	}
+	if err := r.Shutdown(context.Background()); err != nil {
+		t.Errorf("second Shutdown = %v, want nil", err)
+	}
+	if calls != 1 {
+		t.Errorf("Close called %d times, want 1", calls)
+	}
+}
+
+func TestShutdownPassesExpiredContext(t *testing.T) {
+	var r Registry
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var seen []error
+	for _, name := range []string{"a", "b"} {
+		r.Register(name, CloserFunc(func(ctx context.Context) error {
+			seen = append(seen, ctx.Err())
+			return nil
+		}))
+	}
+
+	if err := r.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown = %v", err)
+	}
+	if len(seen) != 2 {
+		t.Fatalf("%d Closers ran, want 2", len(seen))
+	}
+	for i, err := range seen {
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Closer %d saw ctx.Err() = %v, want context.Canceled", i, err)
+		}
+	}
+}
+
+func TestRegisterAfterShutdown(t *testing.T) {
+	var r Registry
+	if err := r.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown = %v", err)
+	}
+
+	called := false
+	err := r.Register("late", CloserFunc(func(ctx context.Context) error {
+		called = true
+		return nil
+	}))
+	if !errors.Is(err, ErrShutDown) {
+		t.Errorf("Register = %v, want ErrShutDown", err)
+	}
+
+	r.Shutdown(context.Background())
+	if called {
+		t.Error("Closer registered after Shutdown was run")
+	}
+}
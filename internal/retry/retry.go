@@ -0,0 +1,54 @@
+// Package retry retries Slack API calls that were rejected by rate limiting.
+package retry
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// wait blocks for d or until ctx is done, whichever comes first. Tests
+// replace it to check the durations WithRetry asks for.
+var wait = func(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// WithRetry calls fn until it succeeds, returns an error other than a
+// *slack.RateLimitedError, or maxAttempts calls have been made. Between
+// attempts it waits for exactly the RetryAfter duration Slack asked for,
+// giving up early with ctx.Err() if ctx is done. Otherwise the last error
+// from fn is returned. A maxAttempts below 1 is treated as 1.
+func WithRetry(ctx context.Context, fn func() error, maxAttempts int) error {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	var err error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		err = fn()
+		if err == nil {
+			return nil
+		}
+
+		var rateLimited *slack.RateLimitedError
+		if !errors.As(err, &rateLimited) || attempt == maxAttempts {
+			return err
+		}
+
+		log.Printf("Slack rate limited (attempt %d/%d), retrying in %s", attempt, maxAttempts, rateLimited.RetryAfter)
+		if err := wait(ctx, rateLimited.RetryAfter); err != nil {
+			return err
+		}
+	}
+	return err
+}
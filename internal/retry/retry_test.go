@@ -0,0 +1,125 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+func TestWithRetry(t *testing.T) {
+	rateLimited := &slack.RateLimitedError{}
+	other := errors.New("channel_not_found")
+
+	tests := []struct {
+		name        string
+		errs        []error
+		maxAttempts int
+		wantCalls   int
+		wantErr     error
+	}{
+		{"success first try", []error{nil}, 3, 1, nil},
+		{"retries rate limit then succeeds", []error{rateLimited, rateLimited, nil}, 3, 3, nil},
+		{"wrapped rate limit is retried", []error{fmt.Errorf("post: %w", rateLimited), nil}, 3, 2, nil},
+		{"other error returned at once", []error{other, nil}, 3, 1, other},
+		{"other error after rate limit", []error{rateLimited, other, nil}, 3, 2, other},
+		{"attempts exhausted returns last error", []error{rateLimited, rateLimited, rateLimited, nil}, 3, 3, rateLimited},
+		{"zero attempts treated as one", []error{rateLimited, nil}, 0, 1, rateLimited},
+		{"negative attempts treated as one", []error{nil}, -2, 1, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			err := WithRetry(context.Background(), func() error {
+				err := tt.errs[calls]
+				calls++
+				return err
+			}, tt.maxAttempts)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestWithRetryStopsWhenContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	calls := 0
+	start := time.Now()
+	err := WithRetry(ctx, func() error {
+		calls++
+		return &slack.RateLimitedError{RetryAfter: time.Hour}
+	}, 3)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+	if time.Since(start) > time.Second {
+		t.Error("WithRetry waited out RetryAfter despite cancelled context")
+	}
+}
+
+func TestWithRetryWaitsRetryAfterExactly(t *testing.T) {
+	var waited []time.Duration
+	defer func(orig func(context.Context, time.Duration) error) { wait = orig }(wait)
+	wait = func(ctx context.Context, d time.Duration) error {
+		waited = append(waited, d)
+		return nil
+	}
+
+	errs := []error{
+		&slack.RateLimitedError{RetryAfter: 3 * time.Second},
+		fmt.Errorf("post: %w", &slack.RateLimitedError{RetryAfter: 1500 * time.Millisecond}),
+		&slack.RateLimitedError{RetryAfter: 30 * time.Second},
+		nil,
+	}
+	calls := 0
+	err := WithRetry(context.Background(), func() error {
+		err := errs[calls]
+		calls++
+		return err
+	}, 5)
+
+	if err != nil {
+		t.Fatalf("err = %v", err)
+	}
+	want := []time.Duration{3 * time.Second, 1500 * time.Millisecond, 30 * time.Second}
+	if len(waited) != len(want) {
+		t.Fatalf("waited %v, want %v", waited, want)
+	}
+	for i := range want {
+		if waited[i] != want[i] {
+			t.Errorf("wait %d = %s, want %s", i, waited[i], want[i])
+		}
+	}
+}
+
+func TestWithRetryDoesNotWaitAfterLastAttempt(t *testing.T) {
+	waits := 0
+	defer func(orig func(context.Context, time.Duration) error) { wait = orig }(wait)
+	wait = func(ctx context.Context, d time.Duration) error {
+		waits++
+		return nil
+	}
+
+	WithRetry(context.Background(), func() error {
+		return &slack.RateLimitedError{RetryAfter: time.Minute}
+	}, 3)
+
+	if waits != 2 {
+		t.Errorf("waited %d times, want 2 (between 3 attempts)", waits)
+	}
+}
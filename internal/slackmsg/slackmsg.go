@@ -0,0 +1,116 @@
+// Package slackmsg keeps rendered lists within Slack's message size limits.
+package slackmsg
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxTextLength is the longest text Slack accepts in a single message.
+// Slack counts characters, so all lengths here are in runes.
+const MaxTextLength = 40000
+
+// Split packs lines, joined by newlines, into as few messages as possible,
+// each at most limit characters. A single line longer than limit is cut
+// into limit-sized pieces. Messages that would be empty or only whitespace
+// are dropped, since Slack rejects them with no_text, so Split returns nil
+// when there is nothing to post.
+func Split(lines []string, limit int) []string {
+	if limit < 1 {
+		limit = MaxTextLength
+	}
+
+	var msgs []string
+	var b strings.Builder
+	n := 0
+	flush := func() {
+		if msg := b.String(); strings.TrimSpace(msg) != "" {
+			msgs = append(msgs, msg)
+		}
+		b.Reset()
+		n = 0
+	}
+
+	first := true
+	for _, line := range lines {
+		for _, piece := range cut(line, limit) {
+			size := utf8.RuneCountInString(piece)
+			switch {
+			case first:
+				first = false
+			case n+1+size > limit:
+				flush()
+			default:
+				b.WriteByte('\n')
+				n++
+			}
+			b.WriteString(piece)
+			n += size
+		}
+	}
+	if !first {
+		flush()
+	}
+	return msgs
+}
+
+// Truncate joins as many leading lines as fit within limit characters and,
+// if any are left out, ends with an "…and N more" footer counted against
+// the limit. If not even the footer fits, Truncate returns just "…", so
+// the result never exceeds limit.
+func Truncate(lines []string, limit int) string {
+	if limit < 1 {
+		limit = MaxTextLength
+	}
+
+	total := 0
+	for i, line := range lines {
+		if i > 0 {
+			total++
+		}
+		total += utf8.RuneCountInString(line)
+	}
+	if total <= limit {
+		return strings.Join(lines, "\n")
+	}
+
+	kept, size := 0, 0
+	for kept < len(lines) {
+		next := size + utf8.RuneCountInString(lines[kept])
+		if kept > 0 {
+			next++
+		}
+		if next+utf8.RuneCountInString("\n"+footer(len(lines)-kept-1)) > limit {
+			break
+		}
+		size = next
+		kept++
+	}
+
+	if kept == 0 {
+		if f := footer(len(lines)); utf8.RuneCountInString(f) <= limit {
+			return f
+		}
+		return "…"
+	}
+	return strings.Join(lines[:kept], "\n") + "\n" + footer(len(lines)-kept)
+}
+
+func footer(more int) string {
+	return fmt.Sprintf("…and %d more", more)
+}
+
+// cut splits s into pieces of at most limit runes.
+func cut(s string, limit int) []string {
+	if utf8.RuneCountInString(s) <= limit {
+		return []string{s}
+	}
+	var pieces []string
+	runes := []rune(s)
+	for len(runes) > limit {
+		pieces = append(pieces, string(runes[:limit]))
+		runes = runes[limit:]
+	}
+	return append(pieces, string(runes))
+}
@@ -0,0 +1,118 @@
+package slackmsg
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func leaderboard(n int) []string {
+	lines := make([]string, n)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("%d. <@U%08d> — %d karma ✨", i+1, i, n-i)
+	}
+	return lines
+}
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		limit int
+		want  []string
+	}{
+		{"no lines", nil, 10, nil},
+		{"fits in one", []string{"a", "b", "c"}, 10, []string{"a\nb\nc"}},
+		{"exactly at limit", []string{"abcd", "efgh"}, 9, []string{"abcd\nefgh"}},
+		{"one over limit", []string{"abcd", "efgh"}, 8, []string{"abcd", "efgh"}},
+		{"packs greedily", []string{"aa", "bb", "cc", "dd"}, 5, []string{"aa\nbb", "cc\ndd"}},
+		{"long line is cut", []string{"abcdefgh", "ij"}, 3, []string{"abc", "def", "gh", "ij"}},
+		{"counts runes not bytes", []string{"🎂🎂", "🎉🎉"}, 5, []string{"🎂🎂\n🎉🎉"}},
+		{"keeps empty lines", []string{"a", "", "b"}, 10, []string{"a\n\nb"}},
+		{"only an empty line", []string{""}, 3, nil},
+		{"only blank lines", []string{"", "  ", ""}, 10, nil},
+		{"leading empty line before full line", []string{"", "abc"}, 3, []string{"abc"}},
+		{"blank message between full lines", []string{"abc", "", "def"}, 3, []string{"abc", "def"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Split(tt.lines, tt.limit)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Split = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitOversizedList(t *testing.T) {
+	lines := leaderboard(5000)
+
+	msgs := Split(lines, MaxTextLength)
+	if len(msgs) < 2 {
+		t.Fatalf("got %d messages, want the list split across several", len(msgs))
+	}
+	for i, msg := range msgs {
+		if n := utf8.RuneCountInString(msg); n > MaxTextLength {
+			t.Errorf("message %d is %d characters, over the %d limit", i, n, MaxTextLength)
+		}
+	}
+	if got := strings.Join(msgs, "\n"); got != strings.Join(lines, "\n") {
+		t.Error("joined messages do not reproduce the original list")
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		limit int
+		want  string
+	}{
+		{"no lines", nil, 10, ""},
+		{"fits", []string{"a", "b"}, 10, "a\nb"},
+		{"drops tail with footer", []string{"1111", "2222", "3333", "4444", "5555", "6666"}, 22, "1111\n2222\n…and 4 more"},
+		{"footer counts against limit", []string{"1111", "2222", "3333", "4444", "5555", "6666"}, 20, "1111\n…and 5 more"},
+		{"nothing fits", []string{"a very long line"}, 12, "…and 1 more"},
+		{"footer does not fit", []string{"aaaa", "b"}, 3, "…"},
+		{"limit of one", []string{"aaaa", "b"}, 1, "…"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Truncate(tt.lines, tt.limit); got != tt.want {
+				t.Errorf("Truncate = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTruncateOversizedList(t *testing.T) {
+	lines := leaderboard(5000)
+
+	got := Truncate(lines, MaxTextLength)
+	if n := utf8.RuneCountInString(got); n > MaxTextLength {
+		t.Fatalf("result is %d characters, over the %d limit", n, MaxTextLength)
+	}
+
+	shown := strings.Split(got, "\n")
+	kept := len(shown) - 1
+	if want := fmt.Sprintf("…and %d more", len(lines)-kept); shown[kept] != want {
+		t.Errorf("footer = %q, want %q", shown[kept], want)
+	}
+	if !reflect.DeepEqual(shown[:kept], lines[:kept]) {
+		t.Error("kept lines are not the leading lines of the list")
+	}
+}
+
+func TestTruncateNeverExceedsLimit(t *testing.T) {
+	lines := leaderboard(20)
+	for limit := 1; limit <= 200; limit++ {
+		got := Truncate(lines, limit)
+		if n := utf8.RuneCountInString(got); n > limit {
+			t.Errorf("Truncate(..., %d) is %d characters: %q", limit, n, got)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+// Package slacktext cleans up Slack message text before it is scanned for
+// mentions, so pasted code and quoted messages don't trigger karma.
+package slacktext
+
+import "regexp"
+
+var (
+	fencedCode = regexp.MustCompile("(?s)```.*?```")
+	inlineCode = regexp.MustCompile("`[^`\n]+`")
+	// Slack escapes ">" in message text as "&gt;"; accept both forms.
+	blockQuote = regexp.MustCompile(`(?ms)^(?:>>>|&gt;&gt;&gt;).*`)
+	lineQuote  = regexp.MustCompile(`(?m)^(?:>|&gt;).*$`)
+)
+
+// StripCodeAndQuotes removes fenced code blocks, inline code spans, quoted
+// lines ("> ...") and multi-line quotes (">>> ...", which run to the end of
+// the message). Inline code is replaced by a space so the words around it
+// stay separate; removed blocks and lines leave their line breaks behind.
+// Unterminated backticks are left as they are, since Slack shows them
+// literally.
+func StripCodeAndQuotes(text string) string {
+	text = fencedCode.ReplaceAllString(text, "\n")
+	text = inlineCode.ReplaceAllString(text, " ")
+	text = blockQuote.ReplaceAllString(text, "")
+	return lineQuote.ReplaceAllString(text, "")
+}
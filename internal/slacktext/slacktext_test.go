@@ -0,0 +1,68 @@
+package slacktext
+
+import (
+	"regexp"
+	"testing"
+)
+
+// karmaPattern mirrors the <@U123>++ form the karma handler looks for.
+var karmaPattern = regexp.MustCompile(`<@([A-Z0-9]+)>\s*\+\+`)
+
+func TestStripCodeAndQuotes(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"plain text untouched", "thanks <@U1>++ for the fix", "thanks <@U1>++ for the fix"},
+		{"fenced code", "look:\n```\nfor i := 0; i < n; i++ { <@U1>++ }\n```\ndone", "look:\n\n\ndone"},
+		{"fenced code on one line", "```<@U1>++``` <@U2>++", "\n <@U2>++"},
+		{"two fenced blocks", "```a++``` keep ```b++```", "\n keep \n"},
+		{"inline code", "run `<@U1>++` then <@U2>++", "run   then <@U2>++"},
+		{"inline code does not span lines", "a ` b\nc ` d", "a ` b\nc ` d"},
+		{"unterminated fence left alone", "```<@U1>++", "```<@U1>++"},
+		{"escaped quote line", "&gt; <@U1>++ great work\n<@U2>++", "\n<@U2>++"},
+		{"raw quote line", "> <@U1>++\nme too <@U2>++", "\nme too <@U2>++"},
+		{"quote mid message", "<@U2>++\n&gt; <@U1>++", "<@U2>++\n"},
+		{"gt inside a line is not a quote", "x &gt; y <@U1>++", "x &gt; y <@U1>++"},
+		{"multi-line quote runs to end", "<@U2>++\n&gt;&gt;&gt; <@U1>++\n<@U3>++", "<@U2>++\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripCodeAndQuotes(tt.input); got != tt.want {
+				t.Errorf("StripCodeAndQuotes(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStripCodeAndQuotesKarmaMatches(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"fenced code", "```\ncount++ // <@U1>++\n```\nthanks <@U2>++", []string{"U2"}},
+		{"inline code", "use `<@U1>++` syntax", nil},
+		{"quote", "&gt; <@U1>++\nagreed", nil},
+		{"outside code still counts", "<@U1>++ for `go vet`", []string{"U1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, m := range karmaPattern.FindAllStringSubmatch(StripCodeAndQuotes(tt.input), -1) {
+				got = append(got, m[1])
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("matched %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("matched %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}